@@ -2,44 +2,61 @@ package unirest
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"errors"
 	"io"
 	"io/ioutil"
 	"mime/multipart"
+	"net"
 	"net/http"
 	"net/url"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
 	"unsafe"
 )
 
 type HTTPClient struct {
-	query     url.Values
-	form      url.Values
-	files     []*fileField
-	url       string
-	path      string
-	body      []byte
-	method    string
-	header    http.Header
-	basicAuth [2]string
-	makeCopy  bool
+	query               url.Values
+	form                url.Values
+	files               []*fileField
+	filePartContentType map[string]string
+	url                 string
+	path                string
+	body                []byte
+	method              string
+	header              http.Header
+	basicAuth           [2]string
+	makeCopy            bool
+	ctx                 context.Context
+	timeout             time.Duration
+	dialTimeout         time.Duration
+	client              *http.Client
+	transport           http.RoundTripper
+	tlsConfig           *tls.Config
+	proxy               func(*http.Request) (*url.URL, error)
+	retry               *RetryPolicy
+	methodSet           bool
+	interceptors        []Interceptor
+	clientCache         *httpClientCache
 }
 
-type fileField struct {
-	key      string
-	filename string
-	content  []byte
-}
+// defaultClient is reused when the caller hasn't customized the transport,
+// timeouts, or TLS configuration.
+var defaultClient = &http.Client{}
 
 func New() *HTTPClient {
 	return &HTTPClient{
-		query:    url.Values{},
-		form:     url.Values{},
-		method:   "GET",
-		header:   http.Header{},
-		files:    make([]*fileField, 0),
-		makeCopy: true,
+		query:               url.Values{},
+		form:                url.Values{},
+		method:              "GET",
+		header:              http.Header{},
+		files:               make([]*fileField, 0),
+		filePartContentType: map[string]string{},
+		makeCopy:            true,
+		clientCache:         &httpClientCache{},
 	}
 }
 
@@ -93,29 +110,90 @@ func (c *HTTPClient) AddFormField(key, value string) *HTTPClient {
 		c = c.Clone()
 	}
 	c.form.Add(key, value)
-	c.method = "POST"
+	c.defaultToPost()
 	return c
 }
 
-func (c *HTTPClient) AddFile(key, filename string, content []byte) *HTTPClient {
+func (c *HTTPClient) SetBasicAuth(username, password string) *HTTPClient {
 	if c.makeCopy {
 		c = c.Clone()
 	}
-	c.files = append(c.files, &fileField{
-		key:      key,
-		filename: filename,
-		content:  content,
-	})
-	c.method = "POST"
+	c.basicAuth[0] = username
+	c.basicAuth[1] = password
 	return c
 }
 
-func (c *HTTPClient) SetBasicAuth(username, password string) *HTTPClient {
+// WithContext attaches ctx to the request, allowing the caller to cancel it
+// or bound its lifetime with a deadline.
+func (c *HTTPClient) WithContext(ctx context.Context) *HTTPClient {
 	if c.makeCopy {
 		c = c.Clone()
 	}
-	c.basicAuth[0] = username
-	c.basicAuth[1] = password
+	c.ctx = ctx
+	return c
+}
+
+// SetTimeout bounds the whole request, including connection, redirects, and
+// reading the response body. It is applied to the underlying http.Client.
+func (c *HTTPClient) SetTimeout(d time.Duration) *HTTPClient {
+	if c.makeCopy {
+		c = c.Clone()
+	}
+	c.timeout = d
+	c.clientCache = &httpClientCache{}
+	return c
+}
+
+// SetDialTimeout bounds establishing the TCP connection.
+func (c *HTTPClient) SetDialTimeout(d time.Duration) *HTTPClient {
+	if c.makeCopy {
+		c = c.Clone()
+	}
+	c.dialTimeout = d
+	c.clientCache = &httpClientCache{}
+	return c
+}
+
+// SetClient overrides the http.Client used to send the request. Timeout,
+// transport, TLS, and proxy overrides are ignored once a client is set;
+// configure them on the client directly.
+func (c *HTTPClient) SetClient(client *http.Client) *HTTPClient {
+	if c.makeCopy {
+		c = c.Clone()
+	}
+	c.client = client
+	return c
+}
+
+// SetTransport overrides the base http.RoundTripper. Dial timeout, TLS, and
+// proxy overrides are layered on top when the transport is an *http.Transport.
+func (c *HTTPClient) SetTransport(transport http.RoundTripper) *HTTPClient {
+	if c.makeCopy {
+		c = c.Clone()
+	}
+	c.transport = transport
+	c.clientCache = &httpClientCache{}
+	return c
+}
+
+// SetTLSConfig sets the TLS configuration used by the lazily-built transport.
+func (c *HTTPClient) SetTLSConfig(config *tls.Config) *HTTPClient {
+	if c.makeCopy {
+		c = c.Clone()
+	}
+	c.tlsConfig = config
+	c.clientCache = &httpClientCache{}
+	return c
+}
+
+// SetProxy sets the proxy function used by the lazily-built transport, e.g.
+// http.ProxyURL(u) or http.ProxyFromEnvironment.
+func (c *HTTPClient) SetProxy(proxy func(*http.Request) (*url.URL, error)) *HTTPClient {
+	if c.makeCopy {
+		c = c.Clone()
+	}
+	c.proxy = proxy
+	c.clientCache = &httpClientCache{}
 	return c
 }
 
@@ -125,7 +203,7 @@ func (c *HTTPClient) SetJSONBody(json []byte) *HTTPClient {
 	}
 	c.body = json
 	c.header.Set("Content-Type", "application/json")
-	c.method = "POST"
+	c.defaultToPost()
 	return c
 }
 
@@ -134,24 +212,67 @@ func (c *HTTPClient) SetRawBody(body []byte) *HTTPClient {
 		c = c.Clone()
 	}
 	c.body = body
-	c.method = "POST"
+	c.defaultToPost()
 	c.header.Del("Content-Type")
 	return c
 }
 
+// defaultToPost switches the method to POST for request bodies added via
+// SetJSONBody/SetRawBody/AddFormField/AddFile*, but only while the method is
+// still New's initial GET; an explicitly-chosen verb (including an explicit
+// Get()) is never overridden.
+func (c *HTTPClient) defaultToPost() {
+	if !c.methodSet {
+		c.method = "POST"
+	}
+}
+
 func (c *HTTPClient) Get() *HTTPClient {
+	return c.Method("GET")
+}
+
+func (c *HTTPClient) Post() *HTTPClient {
+	return c.Method("POST")
+}
+
+func (c *HTTPClient) Put() *HTTPClient {
+	return c.Method("PUT")
+}
+
+func (c *HTTPClient) Patch() *HTTPClient {
+	return c.Method("PATCH")
+}
+
+func (c *HTTPClient) Delete() *HTTPClient {
+	return c.Method("DELETE")
+}
+
+func (c *HTTPClient) Head() *HTTPClient {
+	return c.Method("HEAD")
+}
+
+func (c *HTTPClient) Options() *HTTPClient {
+	return c.Method("OPTIONS")
+}
+
+// Method sets an arbitrary HTTP verb, e.g. for non-standard methods.
+func (c *HTTPClient) Method(verb string) *HTTPClient {
 	if c.makeCopy {
 		c = c.Clone()
 	}
-	c.method = "GET"
+	c.method = verb
+	c.methodSet = true
 	return c
 }
 
-func (c *HTTPClient) Post() *HTTPClient {
+// Use appends interceptors to the chain Send runs the request through,
+// outermost first: the first interceptor passed to the first Use call sees
+// the request before any other.
+func (c *HTTPClient) Use(interceptors ...Interceptor) *HTTPClient {
 	if c.makeCopy {
 		c = c.Clone()
 	}
-	c.method = "POST"
+	c.interceptors = append(append([]Interceptor(nil), c.interceptors...), interceptors...)
 	return c
 }
 
@@ -161,13 +282,151 @@ func (c *HTTPClient) Send() *Response {
 		return &Response{Err: err}
 	}
 
-	var httpclient http.Client
-	resp, err := httpclient.Do(req)
+	var attempts int
+	chain := Next(func(req *http.Request) (*http.Response, error) {
+		resp, err, n := c.roundTrip(req)
+		attempts = n
+		return resp, err
+	})
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		interceptor, next := c.interceptors[i], chain
+		chain = func(req *http.Request) (*http.Response, error) {
+			return interceptor(req, next)
+		}
+	}
+
+	resp, err := chain(req)
 	if err != nil {
-		return &Response{Err: err}
+		return &Response{Err: err, attempts: attempts}
+	}
+	return &Response{Response: resp, attempts: attempts}
+}
+
+// roundTrip sends req, retrying according to c.retry if set, and reports
+// how many attempts were made.
+func (c *HTTPClient) roundTrip(req *http.Request) (*http.Response, error, int) {
+	client := c.httpClient()
+	policy := c.retry
+	if policy == nil {
+		resp, err := client.Do(req)
+		return resp, err, 1
+	}
+
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	retryOn := policy.RetryOn
+	if retryOn == nil {
+		retryOn = DefaultRetryOn
+	}
+
+	var resp *http.Response
+	var err error
+	attempts := 0
+	for i := 1; ; i++ {
+		if i > 1 && req.Body != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				err = bodyErr
+				break
+			}
+			req.Body = body
+		}
+
+		resp, err = client.Do(req)
+		attempts = i
+
+		if attempts >= maxAttempts || !retryOn(resp, err) {
+			break
+		}
+		// A body that can't be rebuilt can't be resent: give up now, before
+		// closing this attempt's response or sleeping the backoff.
+		if req.Body != nil && req.GetBody == nil {
+			break
+		}
+
+		wait := time.Duration(0)
+		if policy.RespectRetryAfter {
+			wait, _ = parseRetryAfter(resp)
+		}
+		if wait == 0 && policy.Backoff != nil {
+			wait = policy.Backoff(attempts)
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		if wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-req.Context().Done():
+				timer.Stop()
+				return nil, req.Context().Err(), attempts
+			case <-timer.C:
+			}
+		}
 	}
 
-	return &Response{Response: resp}
+	return resp, err, attempts
+}
+
+// httpClientCache holds the http.Client lazily built from transport/dial/
+// TLS/proxy overrides, so it (and its connection pool) is built once and
+// reused across repeated Send calls on the same HTTPClient, e.g. one kept
+// around with AutoClone(false). It's held behind a pointer, not embedded
+// directly, so Clone can share it by copying the pointer while setters that
+// change the underlying config swap in a fresh one instead of mutating
+// a cache another clone might already be using.
+type httpClientCache struct {
+	mu     sync.Mutex
+	client *http.Client
+}
+
+// httpClient returns the http.Client to send the request with, lazily
+// building and caching one from the transport/dial/TLS/proxy overrides when
+// the caller hasn't supplied a client of their own.
+func (c *HTTPClient) httpClient() *http.Client {
+	if c.client != nil {
+		return c.client
+	}
+
+	if c.transport == nil && c.tlsConfig == nil && c.proxy == nil && c.dialTimeout == 0 && c.timeout == 0 {
+		return defaultClient
+	}
+
+	c.clientCache.mu.Lock()
+	defer c.clientCache.mu.Unlock()
+	if c.clientCache.client == nil {
+		c.clientCache.client = c.buildHTTPClient()
+	}
+	return c.clientCache.client
+}
+
+func (c *HTTPClient) buildHTTPClient() *http.Client {
+	transport := c.transport
+	if base, ok := transport.(*http.Transport); ok || transport == nil {
+		if base == nil {
+			base = http.DefaultTransport.(*http.Transport).Clone()
+		} else {
+			base = base.Clone()
+		}
+		if c.tlsConfig != nil {
+			base.TLSClientConfig = c.tlsConfig
+		}
+		if c.proxy != nil {
+			base.Proxy = c.proxy
+		}
+		if c.dialTimeout != 0 {
+			base.DialContext = (&net.Dialer{Timeout: c.dialTimeout}).DialContext
+		}
+		transport = base
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   c.timeout,
+	}
 }
 
 func (c *HTTPClient) ParseRequest() (*http.Request, error) {
@@ -196,55 +455,66 @@ func (c *HTTPClient) ParseRequest() (*http.Request, error) {
 		return nil, errors.New("unirest-go: can send this request with multiple content type")
 	}
 
-	var reader *bytes.Reader
-	if c.body != nil {
-		reader = bytes.NewReader(c.body)
-	} else if len(c.files) != 0 {
-		body := &bytes.Buffer{}
-		writer := multipart.NewWriter(body)
+	if c.hasStreamingFiles() {
+		if err := c.attachStreamingMultipart(req); err != nil {
+			return nil, err
+		}
+	} else {
+		var reader *bytes.Reader
+		if c.body != nil {
+			reader = bytes.NewReader(c.body)
+		} else if len(c.files) != 0 {
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+
+			for _, file := range c.files {
+				fw, err := createFormFilePart(writer, file.key, file.filename, c.filePartContentType[file.key])
+				if err != nil {
+					return nil, err
+				}
+				_, err = fw.Write(file.content)
+				if err != nil {
+					return nil, err
+				}
+			}
 
-		for _, file := range c.files {
-			fw, err := writer.CreateFormFile(file.key, file.filename)
-			if err != nil {
-				return nil, err
+			for key, values := range c.form {
+				for _, value := range values {
+					err := writer.WriteField(key, value)
+					if err != nil {
+						return nil, err
+					}
+				}
 			}
-			_, err = fw.Write(file.content)
+
+			err := writer.Close()
 			if err != nil {
 				return nil, err
 			}
-		}
 
-		for key, values := range c.form {
-			for _, value := range values {
-				err := writer.WriteField(key, value)
-				if err != nil {
-					return nil, err
-				}
-			}
+			reader = bytes.NewReader(body.Bytes())
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+		} else if len(c.form) != 0 {
+			reader = bytes.NewReader(s2b(c.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		}
 
-		err := writer.Close()
-		if err != nil {
-			return nil, err
+		if reader != nil {
+			readCloser := ioutil.NopCloser(reader)
+			req.Body = readCloser
+			req.ContentLength = int64(reader.Len())
+			snapshot := *reader
+			req.GetBody = func() (io.ReadCloser, error) {
+				r := snapshot
+				return ioutil.NopCloser(&r), nil
+			}
 		}
+	}
 
-		reader = bytes.NewReader(body.Bytes())
-		req.Header.Set("Content-Type", writer.FormDataContentType())
-	} else if len(c.form) != 0 {
-		reader = bytes.NewReader(s2b(c.form.Encode()))
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	}
-
-	if reader != nil {
-		readCloser := ioutil.NopCloser(reader)
-		req.Body = readCloser
-		req.ContentLength = int64(reader.Len())
-		snapshot := *reader
-		req.GetBody = func() (io.ReadCloser, error) {
-			r := snapshot
-			return ioutil.NopCloser(&r), nil
-		}
+	if c.ctx != nil {
+		req = req.WithContext(c.ctx)
 	}
+
 	return req, nil
 }
 
@@ -253,6 +523,9 @@ func (c *HTTPClient) Clone() *HTTPClient {
 	clone.query = copyMap(c.query)
 	clone.header = copyMap(c.header)
 	clone.form = copyMap(c.form)
+	clone.files = append([]*fileField(nil), c.files...)
+	clone.filePartContentType = copyStringMap(c.filePartContentType)
+	clone.interceptors = append([]Interceptor(nil), c.interceptors...)
 	return &clone
 }
 
@@ -265,6 +538,14 @@ func copyMap(m map[string][]string) map[string][]string {
 	return clone
 }
 
+func copyStringMap(m map[string]string) map[string]string {
+	clone := map[string]string{}
+	for key, value := range m {
+		clone[key] = value
+	}
+	return clone
+}
+
 // b2s converts byte slice to a string without memory allocation.
 // See https://groups.google.com/forum/#!msg/Golang-Nuts/ENgbUzYvCuU/90yGx7GUAgAJ .
 //
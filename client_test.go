@@ -1,10 +1,13 @@
 package unirest
 
 import (
+	"bytes"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -131,6 +134,134 @@ func TestSimpleFile(t *testing.T) {
 	assert.Equal(t, "true", c)
 }
 
+func TestStreamingFileReader(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expected := true
+
+		_, f, err := r.FormFile("file1")
+		if err != nil {
+			panic(err)
+		}
+		expected = expected && f.Filename == "file1.txt"
+		file, _ := f.Open()
+		b, _ := ioutil.ReadAll(file)
+		expected = expected && string(b) == "streamed content"
+
+		vv := r.PostFormValue("field1")
+		expected = expected && vv == "1"
+
+		if expected {
+			w.Write([]byte("true"))
+		} else {
+			w.Write([]byte("false"))
+		}
+	}))
+	defer svr.Close()
+	c, err := New().SetURL(svr.URL).
+		AddFileReader("file1", "file1.txt", bytes.NewReader([]byte("streamed content"))).
+		AddFormField("field1", "1").
+		Send().
+		AsString()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "true", c)
+}
+
+func TestStreamingFilePath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file1.txt")
+	err := ioutil.WriteFile(path, []byte("from disk"), 0600)
+	assert.NoError(t, err)
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expected := true
+
+		_, f, err := r.FormFile("file1")
+		if err != nil {
+			panic(err)
+		}
+		expected = expected && f.Filename == "file1.txt"
+		file, _ := f.Open()
+		b, _ := ioutil.ReadAll(file)
+		expected = expected && string(b) == "from disk"
+
+		if expected {
+			w.Write([]byte("true"))
+		} else {
+			w.Write([]byte("false"))
+		}
+	}))
+	defer svr.Close()
+	c, err := New().SetURL(svr.URL).
+		AddFilePath("file1", path).
+		Send().
+		AsString()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "true", c)
+}
+
+func TestStreamingAndBufferedFilesMixed(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expected := true
+
+		_, f, err := r.FormFile("small")
+		if err != nil {
+			panic(err)
+		}
+		file, _ := f.Open()
+		b, _ := ioutil.ReadAll(file)
+		expected = expected && string(b) == "hello"
+
+		_, f, err = r.FormFile("big")
+		if err != nil {
+			panic(err)
+		}
+		file, _ = f.Open()
+		b, _ = ioutil.ReadAll(file)
+		expected = expected && string(b) == "world"
+
+		if expected {
+			w.Write([]byte("true"))
+		} else {
+			w.Write([]byte("false"))
+		}
+	}))
+	defer svr.Close()
+	c, err := New().SetURL(svr.URL).
+		AddFile("small", "a.txt", []byte("hello")).
+		AddFileReader("big", "b.txt", bytes.NewReader([]byte("world"))).
+		Send().
+		AsString()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "true", c)
+}
+
+func TestSetFilePartContentType(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, f, err := r.FormFile("file1")
+		if err != nil {
+			panic(err)
+		}
+
+		if f.Header.Get("Content-Type") == "text/plain" {
+			w.Write([]byte("true"))
+		} else {
+			w.Write([]byte("false"))
+		}
+	}))
+	defer svr.Close()
+	c, err := New().SetURL(svr.URL).
+		AddFile("file1", "file1.txt", []byte("file1")).
+		SetFilePartContentType("file1", "text/plain").
+		Send().
+		AsString()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "true", c)
+}
+
 func TestSimpleBody(t *testing.T) {
 	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		expected := true
@@ -231,6 +362,17 @@ func TestClone(t *testing.T) {
 	return
 }
 
+func TestHTTPClientIsCachedAndReused(t *testing.T) {
+	c := New().AutoClone(false).SetDialTimeout(time.Second)
+	a := c.httpClient()
+	b := c.httpClient()
+	assert.Same(t, a, b)
+
+	c2 := c.SetDialTimeout(2 * time.Second)
+	d := c2.httpClient()
+	assert.NotSame(t, a, d)
+}
+
 func TestAddPath(t *testing.T) {
 	c := New().SetURL("https://a.com/").
 		AppendPath("p1").
@@ -245,3 +387,74 @@ func TestExpectedError(t *testing.T) {
 	_, err := New().AddFormField("1", "1").SetRawBody([]byte("123")).Send().AsBytes()
 	assert.Error(t, err, "unirest-go: can send this request with multiple content type")
 }
+
+func TestMethodVerbs(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Method))
+	}))
+	defer svr.Close()
+
+	m, err := New().SetURL(svr.URL).Patch().Send().AsString()
+	assert.NoError(t, err)
+	assert.Equal(t, "PATCH", m)
+
+	m, err = New().SetURL(svr.URL).Delete().Send().AsString()
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETE", m)
+
+	m, err = New().SetURL(svr.URL).Options().Send().AsString()
+	assert.NoError(t, err)
+	assert.Equal(t, "OPTIONS", m)
+
+	m, err = New().SetURL(svr.URL).Method("TRACE").Send().AsString()
+	assert.NoError(t, err)
+	assert.Equal(t, "TRACE", m)
+}
+
+func TestPutJSONBody(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expected := r.Method == http.MethodPut
+		b, _ := ioutil.ReadAll(r.Body)
+		expected = expected && string(b) == "{\"A\":1}"
+
+		if expected {
+			w.Write([]byte("true"))
+		} else {
+			w.Write([]byte("false"))
+		}
+	}))
+	defer svr.Close()
+	c, err := New().SetURL(svr.URL).Put().SetJSONBody([]byte("{\"A\":1}")).Send().AsString()
+	assert.NoError(t, err)
+	assert.Equal(t, "true", c)
+}
+
+func TestUseRunsInOrder(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer svr.Close()
+
+	var order []string
+	track := func(name string) Interceptor {
+		return func(req *http.Request, next Next) (*http.Response, error) {
+			order = append(order, name)
+			return next(req)
+		}
+	}
+
+	c, err := New().SetURL(svr.URL).Use(track("outer"), track("inner")).Send().AsString()
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", c)
+	assert.Equal(t, []string{"outer", "inner"}, order)
+}
+
+func TestExplicitMethodNotOverridden(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Method))
+	}))
+	defer svr.Close()
+	m, err := New().SetURL(svr.URL).Put().AddFormField("field1", "1").Send().AsString()
+	assert.NoError(t, err)
+	assert.Equal(t, "PUT", m)
+}
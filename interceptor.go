@@ -0,0 +1,176 @@
+package unirest
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Next is the remainder of the interceptor chain.
+type Next func(*http.Request) (*http.Response, error)
+
+// Interceptor wraps a request, optionally inspecting or rewriting it before
+// calling next, and inspecting or rewriting the response/error it returns.
+type Interceptor func(req *http.Request, next Next) (*http.Response, error)
+
+// LoggingInterceptor writes one line per request to w: method, URL, status,
+// and latency.
+func LoggingInterceptor(w io.Writer) Interceptor {
+	return func(req *http.Request, next Next) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next(req)
+		latency := time.Since(start)
+
+		status := "error"
+		if resp != nil {
+			status = resp.Status
+		}
+		fmt.Fprintf(w, "%s %s %s %s\n", req.Method, req.URL, status, latency)
+		return resp, err
+	}
+}
+
+// BearerAuth sets the Authorization header to a bearer token obtained from
+// tokenProvider on every request, e.g. to refresh a short-lived token.
+func BearerAuth(tokenProvider func(ctx context.Context) (string, error)) Interceptor {
+	return func(req *http.Request, next Next) (*http.Response, error) {
+		token, err := tokenProvider(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		return next(req)
+	}
+}
+
+// SigningInterceptor signs the method, URL, and a timestamp with HMAC-SHA256
+// under key, and sends the result as X-Signature-Timestamp/X-Signature
+// headers. It never reads the body, so it's safe to use with streaming
+// uploads.
+func SigningInterceptor(key []byte) Interceptor {
+	return func(req *http.Request, next Next) (*http.Response, error) {
+		ts := time.Now().UTC().Format(time.RFC3339)
+
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(req.Method))
+		mac.Write([]byte(req.URL.String()))
+		mac.Write([]byte(ts))
+
+		req.Header.Set("X-Signature-Timestamp", ts)
+		req.Header.Set("X-Signature", hex.EncodeToString(mac.Sum(nil)))
+		return next(req)
+	}
+}
+
+// GzipRequestInterceptor transparently gzip-compresses the request body and
+// sets Content-Encoding: gzip. It disables req.GetBody, since a compressed
+// stream can't be replayed without re-running the compressor; requests sent
+// through it are not retried.
+func GzipRequestInterceptor() Interceptor {
+	return func(req *http.Request, next Next) (*http.Response, error) {
+		if req.Body == nil || req.Method == http.MethodGet || req.Method == http.MethodHead {
+			return next(req)
+		}
+
+		original := req.Body
+		pr, pw := io.Pipe()
+		gz := gzip.NewWriter(pw)
+		go func() {
+			_, err := io.Copy(gz, original)
+			original.Close()
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			pw.CloseWithError(gz.Close())
+		}()
+
+		req.Body = pr
+		req.ContentLength = -1
+		req.GetBody = nil
+		req.Header.Set("Content-Encoding", "gzip")
+		return next(req)
+	}
+}
+
+// CircuitBreaker trips after threshold consecutive failures (network errors
+// or 5xx responses) and rejects requests until resetTimeout has elapsed,
+// after which it allows one request through to probe recovery.
+type CircuitBreaker struct {
+	mu           sync.Mutex
+	threshold    int
+	resetTimeout time.Duration
+	failures     int
+	openedAt     time.Time
+	halfOpen     bool
+}
+
+func NewCircuitBreaker(threshold int, resetTimeout time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{threshold: threshold, resetTimeout: resetTimeout}
+}
+
+// allow reports whether a request may proceed. Once open, only a single
+// caller is let through as a probe once resetTimeout has elapsed; every
+// other concurrent caller is rejected until that probe's record() resolves.
+func (b *CircuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.halfOpen {
+		return false
+	}
+	if time.Since(b.openedAt) > b.resetTimeout {
+		b.halfOpen = true
+		return true
+	}
+	return false
+}
+
+func (b *CircuitBreaker) record(resp *http.Response, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	failed := err != nil || (resp != nil && resp.StatusCode >= 500)
+
+	if b.halfOpen {
+		b.halfOpen = false
+		if failed {
+			b.failures = b.threshold
+			b.openedAt = time.Now()
+		} else {
+			b.failures = 0
+		}
+		return
+	}
+
+	if failed {
+		b.failures++
+		if b.failures == b.threshold {
+			b.openedAt = time.Now()
+		}
+		return
+	}
+	b.failures = 0
+}
+
+// CircuitBreakerInterceptor short-circuits requests while breaker is open,
+// returning an error instead of calling next.
+func CircuitBreakerInterceptor(breaker *CircuitBreaker) Interceptor {
+	return func(req *http.Request, next Next) (*http.Response, error) {
+		if !breaker.allow() {
+			return nil, errors.New("unirest-go: circuit breaker open")
+		}
+		resp, err := next(req)
+		breaker.record(resp, err)
+		return resp, err
+	}
+}
@@ -0,0 +1,219 @@
+package unirest
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGzipRequestInterceptorCompressesBody(t *testing.T) {
+	var gotEncoding string
+	var gotBody string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotEncoding = r.Header.Get("Content-Encoding")
+		gz, err := gzip.NewReader(r.Body)
+		assert.NoError(t, err)
+		b, err := ioutil.ReadAll(gz)
+		assert.NoError(t, err)
+		gotBody = string(b)
+		w.Write([]byte("ok"))
+	}))
+	defer svr.Close()
+
+	c, err := New().SetURL(svr.URL).
+		Use(GzipRequestInterceptor()).
+		SetRawBody([]byte("hello world")).
+		Send().
+		AsString()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", c)
+	assert.Equal(t, "gzip", gotEncoding)
+	assert.Equal(t, "hello world", gotBody)
+}
+
+func TestGzipRequestInterceptorClearsGetBody(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer svr.Close()
+
+	var sawGetBody bool
+	capture := Interceptor(func(req *http.Request, next Next) (*http.Response, error) {
+		resp, err := next(req)
+		sawGetBody = req.GetBody != nil
+		return resp, err
+	})
+
+	_, err := New().SetURL(svr.URL).
+		Use(capture, GzipRequestInterceptor()).
+		SetRawBody([]byte("hello")).
+		Send().
+		AsString()
+
+	assert.NoError(t, err)
+	assert.False(t, sawGetBody)
+}
+
+func TestBearerAuthSetsHeader(t *testing.T) {
+	var gotAuth string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Write([]byte("ok"))
+	}))
+	defer svr.Close()
+
+	c, err := New().SetURL(svr.URL).
+		Use(BearerAuth(func(ctx context.Context) (string, error) {
+			return "tok123", nil
+		})).
+		Send().
+		AsString()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", c)
+	assert.Equal(t, "Bearer tok123", gotAuth)
+}
+
+func TestBearerAuthSurfacesTokenProviderError(t *testing.T) {
+	var hits int
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Write([]byte("ok"))
+	}))
+	defer svr.Close()
+
+	wantErr := errors.New("token unavailable")
+	resp := New().SetURL(svr.URL).
+		Use(BearerAuth(func(ctx context.Context) (string, error) {
+			return "", wantErr
+		})).
+		Send()
+
+	assert.Equal(t, wantErr, resp.Err)
+	assert.Equal(t, 0, hits)
+}
+
+func TestSigningInterceptorProducesVerifiableHMAC(t *testing.T) {
+	key := []byte("shared-secret")
+
+	var gotMethod, gotURL, gotTimestamp, gotSignature string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer svr.Close()
+
+	capture := Interceptor(func(req *http.Request, next Next) (*http.Response, error) {
+		resp, err := next(req)
+		gotMethod = req.Method
+		gotURL = req.URL.String()
+		gotTimestamp = req.Header.Get("X-Signature-Timestamp")
+		gotSignature = req.Header.Get("X-Signature")
+		return resp, err
+	})
+
+	c, err := New().SetURL(svr.URL).
+		Use(capture, SigningInterceptor(key)).
+		Send().
+		AsString()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", c)
+	assert.NotEmpty(t, gotTimestamp)
+	assert.NotEmpty(t, gotSignature)
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(gotMethod))
+	mac.Write([]byte(gotURL))
+	mac.Write([]byte(gotTimestamp))
+	want := hex.EncodeToString(mac.Sum(nil))
+	assert.Equal(t, want, gotSignature)
+}
+
+func TestSigningInterceptorSignatureChangesWithKey(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer svr.Close()
+
+	var sigA, sigB string
+	capture := func(dst *string) Interceptor {
+		return func(req *http.Request, next Next) (*http.Response, error) {
+			resp, err := next(req)
+			*dst = req.Header.Get("X-Signature")
+			return resp, err
+		}
+	}
+
+	_, err := New().SetURL(svr.URL).Use(capture(&sigA), SigningInterceptor([]byte("key-a"))).Send().AsString()
+	assert.NoError(t, err)
+	_, err = New().SetURL(svr.URL).Use(capture(&sigB), SigningInterceptor([]byte("key-b"))).Send().AsString()
+	assert.NoError(t, err)
+
+	assert.NotEmpty(t, sigA)
+	assert.NotEmpty(t, sigB)
+	assert.NotEqual(t, sigA, sigB)
+}
+
+func TestCircuitBreakerInterceptorOpensAfterThreshold(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer svr.Close()
+
+	breaker := NewCircuitBreaker(2, time.Hour)
+	do := func() *Response {
+		return New().SetURL(svr.URL).Use(CircuitBreakerInterceptor(breaker)).Send()
+	}
+
+	first := do()
+	assert.True(t, first.IsError())
+	assert.NoError(t, first.Err)
+
+	second := do()
+	assert.True(t, second.IsError())
+	assert.NoError(t, second.Err)
+
+	third := do()
+	assert.Error(t, third.Err)
+	assert.Equal(t, "unirest-go: circuit breaker open", third.Err.Error())
+}
+
+func TestCircuitBreakerInterceptorRejectsUntilResetTimeout(t *testing.T) {
+	var hits int
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer svr.Close()
+
+	breaker := NewCircuitBreaker(1, 30*time.Millisecond)
+	do := func() *Response {
+		return New().SetURL(svr.URL).Use(CircuitBreakerInterceptor(breaker)).Send()
+	}
+
+	do()
+	assert.Equal(t, 1, hits)
+
+	rejected := do()
+	assert.Error(t, rejected.Err)
+	assert.Equal(t, "unirest-go: circuit breaker open", rejected.Err.Error())
+	assert.Equal(t, 1, hits)
+
+	time.Sleep(40 * time.Millisecond)
+
+	probe := do()
+	assert.Equal(t, 2, hits)
+	assert.True(t, probe.IsError())
+	assert.NoError(t, probe.Err)
+}
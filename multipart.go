@@ -0,0 +1,191 @@
+package unirest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type fileField struct {
+	key      string
+	filename string
+	content  []byte
+	reader   io.Reader
+	path     string
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func (c *HTTPClient) AddFile(key, filename string, content []byte) *HTTPClient {
+	if c.makeCopy {
+		c = c.Clone()
+	}
+	c.files = append(c.files, &fileField{
+		key:      key,
+		filename: filename,
+		content:  content,
+	})
+	c.defaultToPost()
+	return c
+}
+
+// AddFileReader streams content from r as a multipart file part, without
+// buffering it into memory first. r must be an io.Seeker if the request may
+// need to be replayed (redirects, retries).
+func (c *HTTPClient) AddFileReader(key, filename string, r io.Reader) *HTTPClient {
+	if c.makeCopy {
+		c = c.Clone()
+	}
+	c.files = append(c.files, &fileField{
+		key:      key,
+		filename: filename,
+		reader:   r,
+	})
+	c.defaultToPost()
+	return c
+}
+
+// AddFilePath streams the file at path as a multipart file part. The file is
+// opened lazily when the request is sent, and closed once it has been
+// written, so arbitrarily large files never sit fully in memory.
+func (c *HTTPClient) AddFilePath(key, path string) *HTTPClient {
+	if c.makeCopy {
+		c = c.Clone()
+	}
+	c.files = append(c.files, &fileField{
+		key:      key,
+		filename: filepath.Base(path),
+		path:     path,
+	})
+	c.defaultToPost()
+	return c
+}
+
+// SetFilePartContentType overrides the Content-Type header of the file part
+// registered under key, which otherwise defaults to application/octet-stream.
+func (c *HTTPClient) SetFilePartContentType(key, contentType string) *HTTPClient {
+	if c.makeCopy {
+		c = c.Clone()
+	}
+	c.filePartContentType[key] = contentType
+	return c
+}
+
+func (c *HTTPClient) hasStreamingFiles() bool {
+	for _, file := range c.files {
+		if file.reader != nil || file.path != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// attachStreamingMultipart wires req.Body to the read end of an io.Pipe and
+// fills it from a goroutine, so multi-gigabyte uploads are never buffered in
+// full. req.GetBody replays by re-running the same write sequence, which only
+// works when every reader-backed part is an io.Seeker.
+func (c *HTTPClient) attachStreamingMultipart(req *http.Request) error {
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(c.writeMultipartBody(writer))
+	}()
+
+	req.Body = pr
+	req.ContentLength = -1
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	// Only offer a replay path when every reader-backed part can be rewound;
+	// otherwise leave GetBody nil so retries/redirects fail fast instead of
+	// sending a truncated body.
+	if c.filesSeekable() {
+		req.GetBody = func() (io.ReadCloser, error) {
+			for _, file := range c.files {
+				if file.reader != nil {
+					if _, err := file.reader.(io.Seeker).Seek(0, io.SeekStart); err != nil {
+						return nil, err
+					}
+				}
+			}
+
+			pr2, pw2 := io.Pipe()
+			w2 := multipart.NewWriter(pw2)
+			go func() {
+				pw2.CloseWithError(c.writeMultipartBody(w2))
+			}()
+			return pr2, nil
+		}
+	}
+	return nil
+}
+
+func (c *HTTPClient) writeMultipartBody(writer *multipart.Writer) error {
+	defer writer.Close()
+
+	for _, file := range c.files {
+		fw, err := createFormFilePart(writer, file.key, file.filename, c.filePartContentType[file.key])
+		if err != nil {
+			return err
+		}
+
+		var r io.Reader
+		switch {
+		case file.path != "":
+			f, err := os.Open(file.path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		case file.reader != nil:
+			r = file.reader
+		default:
+			r = bytes.NewReader(file.content)
+		}
+
+		if _, err := io.Copy(fw, r); err != nil {
+			return err
+		}
+	}
+
+	for key, values := range c.form {
+		for _, value := range values {
+			if err := writer.WriteField(key, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *HTTPClient) filesSeekable() bool {
+	for _, file := range c.files {
+		if file.reader == nil {
+			continue
+		}
+		if _, ok := file.reader.(io.Seeker); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// createFormFilePart is CreateFormFile with an overridable Content-Type;
+// multipart.Writer always defaults it to application/octet-stream.
+func createFormFilePart(writer *multipart.Writer, fieldname, filename, contentType string) (io.Writer, error) {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+		quoteEscaper.Replace(fieldname), quoteEscaper.Replace(filename)))
+	h.Set("Content-Type", contentType)
+	return writer.CreatePart(h)
+}
@@ -1,14 +1,29 @@
 package unirest
 
 import (
+	"encoding/json"
+	"encoding/xml"
 	"errors"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"os"
+	"strings"
 )
 
 type Response struct {
 	*http.Response
-	Err error
+	Err      error
+	attempts int
+}
+
+// Attempts returns how many times the request was sent, including the
+// first try. It is 0 if the request was never sent (e.g. ParseRequest
+// failed).
+func (r *Response) Attempts() int {
+	return r.attempts
 }
 
 func (r *Response) AsBytes() ([]byte, error) {
@@ -36,3 +51,109 @@ func (r *Response) AsString() (string, error) {
 
 	return b2s(buf), nil
 }
+
+// AsJSON decodes the response body as JSON into v.
+func (r *Response) AsJSON(v interface{}) error {
+	buf, err := r.AsBytes()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(buf, v)
+}
+
+// AsXML decodes the response body as XML into v.
+func (r *Response) AsXML(v interface{}) error {
+	buf, err := r.AsBytes()
+	if err != nil {
+		return err
+	}
+	return xml.Unmarshal(buf, v)
+}
+
+// BindTo decodes the response body into v based on the response's
+// Content-Type header: JSON and XML unmarshal into v, form-urlencoded
+// requires v to be a *url.Values, and text requires v to be a *string.
+func (r *Response) BindTo(v interface{}) error {
+	if r.Err != nil {
+		return r.Err
+	}
+	if r.Response == nil {
+		return errors.New("the request is not sent")
+	}
+
+	ct := r.Response.Header.Get("Content-Type")
+	switch {
+	case strings.Contains(ct, "json"):
+		return r.AsJSON(v)
+	case strings.Contains(ct, "xml"):
+		return r.AsXML(v)
+	case strings.Contains(ct, "form-urlencoded"):
+		s, ok := v.(*url.Values)
+		if !ok {
+			return errors.New("unirest-go: BindTo target must be *url.Values for a form-urlencoded response")
+		}
+		buf, err := r.AsBytes()
+		if err != nil {
+			return err
+		}
+		values, err := url.ParseQuery(b2s(buf))
+		if err != nil {
+			return err
+		}
+		*s = values
+		return nil
+	case ct == "" || strings.Contains(ct, "text"):
+		s, ok := v.(*string)
+		if !ok {
+			return errors.New("unirest-go: BindTo target must be *string for a text response")
+		}
+		str, err := r.AsString()
+		if err != nil {
+			return err
+		}
+		*s = str
+		return nil
+	default:
+		return fmt.Errorf("unirest-go: BindTo does not support content type %q", ct)
+	}
+}
+
+// IsSuccess reports whether the request succeeded and got a 2xx status.
+func (r *Response) IsSuccess() bool {
+	return r.Err == nil && r.Response != nil && r.Response.StatusCode >= 200 && r.Response.StatusCode < 300
+}
+
+// IsError reports whether the request failed or got a non-2xx status.
+func (r *Response) IsError() bool {
+	return !r.IsSuccess()
+}
+
+// StatusCode returns the response status code, or 0 if the request was
+// never sent or failed before receiving one.
+func (r *Response) StatusCode() int {
+	if r.Err != nil || r.Response == nil {
+		return 0
+	}
+	return r.Response.StatusCode
+}
+
+// SaveToFile streams the response body to the file at path, without
+// buffering it through AsBytes first.
+func (r *Response) SaveToFile(path string) error {
+	if r.Err != nil {
+		return r.Err
+	}
+	if r.Response == nil {
+		return errors.New("the request is not sent")
+	}
+	defer r.Response.Body.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, r.Response.Body)
+	return err
+}
@@ -0,0 +1,144 @@
+package unirest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsJSON(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"A":1}`))
+	}))
+	defer svr.Close()
+
+	var v struct{ A int }
+	err := New().SetURL(svr.URL).Send().AsJSON(&v)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v.A)
+}
+
+func TestAsXML(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		w.Write([]byte(`<Root><A>1</A></Root>`))
+	}))
+	defer svr.Close()
+
+	var v struct {
+		A int
+	}
+	err := New().SetURL(svr.URL).Send().AsXML(&v)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v.A)
+}
+
+func TestBindToJSON(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write([]byte(`{"A":1}`))
+	}))
+	defer svr.Close()
+
+	var v struct{ A int }
+	err := New().SetURL(svr.URL).Send().BindTo(&v)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v.A)
+}
+
+func TestBindToFormURLEncoded(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-www-form-urlencoded")
+		w.Write([]byte("a=1&b=2"))
+	}))
+	defer svr.Close()
+
+	var v url.Values
+	err := New().SetURL(svr.URL).Send().BindTo(&v)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", v.Get("a"))
+	assert.Equal(t, "2", v.Get("b"))
+}
+
+func TestBindToText(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("plain text"))
+	}))
+	defer svr.Close()
+
+	var s string
+	err := New().SetURL(svr.URL).Send().BindTo(&s)
+	assert.NoError(t, err)
+	assert.Equal(t, "plain text", s)
+}
+
+func TestBindToUnsupportedContentType(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write([]byte("binary"))
+	}))
+	defer svr.Close()
+
+	var s string
+	err := New().SetURL(svr.URL).Send().BindTo(&s)
+	assert.Error(t, err)
+}
+
+func TestIsSuccessAndIsError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+
+	ok := New().SetURL(svr.URL).Send()
+	assert.True(t, ok.IsSuccess())
+	assert.False(t, ok.IsError())
+	assert.Equal(t, http.StatusOK, ok.StatusCode())
+
+	bad := New().SetURL(svr.URL).AppendPath("/fail").Send()
+	assert.False(t, bad.IsSuccess())
+	assert.True(t, bad.IsError())
+	assert.Equal(t, http.StatusInternalServerError, bad.StatusCode())
+}
+
+func TestStatusCodeWhenNotSent(t *testing.T) {
+	resp := New().Send()
+	assert.Equal(t, 0, resp.StatusCode())
+	assert.True(t, resp.IsError())
+}
+
+func TestSaveToFile(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("file contents"))
+	}))
+	defer svr.Close()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.txt")
+	err := New().SetURL(svr.URL).Send().SaveToFile(path)
+	assert.NoError(t, err)
+
+	b, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "file contents", string(b))
+}
+
+func TestSaveToFileNotSent(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.txt")
+	err := New().Send().SaveToFile(path)
+	assert.Error(t, err)
+	_, statErr := os.Stat(path)
+	assert.True(t, os.IsNotExist(statErr))
+}
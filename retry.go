@@ -0,0 +1,81 @@
+package unirest
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures automatic retries for Send. MaxAttempts counts the
+// initial try, so MaxAttempts: 3 means up to two retries.
+type RetryPolicy struct {
+	MaxAttempts       int
+	Backoff           func(attempt int) time.Duration
+	RetryOn           func(resp *http.Response, err error) bool
+	RespectRetryAfter bool
+}
+
+// ConstantBackoff waits the same duration before every retry.
+func ConstantBackoff(d time.Duration) func(attempt int) time.Duration {
+	return func(int) time.Duration {
+		return d
+	}
+}
+
+// ExponentialBackoff doubles the wait from base on each attempt, capped at
+// max, with up to jitter (0-1) of random variance added to avoid thundering
+// herds of retrying clients.
+func ExponentialBackoff(base, max time.Duration, jitter float64) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		d := time.Duration(float64(base) * math.Pow(2, float64(attempt-1)))
+		if d <= 0 || d > max {
+			d = max
+		}
+		if jitter > 0 {
+			d += time.Duration(rand.Float64() * jitter * float64(d))
+		}
+		return d
+	}
+}
+
+// DefaultRetryOn retries network errors and 429/5xx responses.
+func DefaultRetryOn(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	if resp == nil {
+		return false
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// SetRetry enables automatic retries according to policy. MaxAttempts <= 0
+// and a nil RetryOn fall back to 1 attempt and DefaultRetryOn respectively.
+func (c *HTTPClient) SetRetry(policy RetryPolicy) *HTTPClient {
+	if c.makeCopy {
+		c = c.Clone()
+	}
+	c.retry = &policy
+	return c
+}
+
+// parseRetryAfter reads the Retry-After header, which may be given in
+// seconds or as an HTTP-date.
+func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t), true
+	}
+	return 0, false
+}
@@ -0,0 +1,143 @@
+package unirest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetrySucceedsAfterFailures(t *testing.T) {
+	var hits int
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if hits < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer svr.Close()
+
+	resp := New().SetURL(svr.URL).
+		SetRetry(RetryPolicy{MaxAttempts: 5, Backoff: ConstantBackoff(0)}).
+		Send()
+
+	body, err := resp.AsString()
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", body)
+	assert.Equal(t, 3, hits)
+	assert.Equal(t, 3, resp.Attempts())
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	var hits int
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer svr.Close()
+
+	resp := New().SetURL(svr.URL).
+		SetRetry(RetryPolicy{MaxAttempts: 3, Backoff: ConstantBackoff(0)}).
+		Send()
+
+	assert.True(t, resp.IsError())
+	assert.Equal(t, 3, hits)
+	assert.Equal(t, 3, resp.Attempts())
+}
+
+func TestRetryDoesNotRetryNonRetryableStatus(t *testing.T) {
+	var hits int
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer svr.Close()
+
+	resp := New().SetURL(svr.URL).
+		SetRetry(RetryPolicy{MaxAttempts: 5, Backoff: ConstantBackoff(0)}).
+		Send()
+
+	assert.Equal(t, 1, hits)
+	assert.Equal(t, 1, resp.Attempts())
+}
+
+func TestRetryReplaysFormBody(t *testing.T) {
+	var hits int
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if hits < 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		vv := r.PostFormValue("field1")
+		if vv == "1" {
+			w.Write([]byte("true"))
+		} else {
+			w.Write([]byte("false"))
+		}
+	}))
+	defer svr.Close()
+
+	c, err := New().SetURL(svr.URL).
+		AddFormField("field1", "1").
+		SetRetry(RetryPolicy{MaxAttempts: 3, Backoff: ConstantBackoff(0)}).
+		Send().
+		AsString()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "true", c)
+	assert.Equal(t, 2, hits)
+}
+
+func TestConstantBackoff(t *testing.T) {
+	backoff := ConstantBackoff(50 * time.Millisecond)
+	assert.Equal(t, 50*time.Millisecond, backoff(1))
+	assert.Equal(t, 50*time.Millisecond, backoff(5))
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(10*time.Millisecond, 100*time.Millisecond, 0)
+	assert.Equal(t, 10*time.Millisecond, backoff(1))
+	assert.Equal(t, 20*time.Millisecond, backoff(2))
+	assert.Equal(t, 40*time.Millisecond, backoff(3))
+	assert.Equal(t, 100*time.Millisecond, backoff(10))
+}
+
+func TestDefaultRetryOn(t *testing.T) {
+	assert.True(t, DefaultRetryOn(nil, assert.AnError))
+	assert.True(t, DefaultRetryOn(&http.Response{StatusCode: 500}, nil))
+	assert.True(t, DefaultRetryOn(&http.Response{StatusCode: 429}, nil))
+	assert.False(t, DefaultRetryOn(&http.Response{StatusCode: 200}, nil))
+	assert.False(t, DefaultRetryOn(&http.Response{StatusCode: 400}, nil))
+}
+
+func TestRetryRespectsRetryAfterSeconds(t *testing.T) {
+	var hits int
+	var gap time.Duration
+	var last time.Time
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if hits == 1 {
+			last = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		gap = time.Since(last)
+		w.Write([]byte("ok"))
+	}))
+	defer svr.Close()
+
+	c, err := New().SetURL(svr.URL).
+		SetRetry(RetryPolicy{MaxAttempts: 2, RespectRetryAfter: true}).
+		Send().
+		AsString()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", c)
+	assert.GreaterOrEqual(t, gap, 900*time.Millisecond)
+}